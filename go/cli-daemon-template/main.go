@@ -1,10 +1,12 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"os"
-	"os/signal"
-	"syscall"
+	"strconv"
+	"strings"
 	"time"
 
 	"cli-daemon-template/service"
@@ -22,7 +24,30 @@ func main() {
 	}
 
 	command := os.Args[1]
-	sm := service.NewServiceManager(serviceName, serviceDesc)
+
+	if command == "list" {
+		runList(os.Args[2:])
+		return
+	}
+
+	if command == "logs" {
+		runLogs(os.Args[2:])
+		return
+	}
+
+	scope := service.SystemScope
+	if hasUserFlag(os.Args[2:]) {
+		scope = service.UserScope
+	}
+
+	name := instanceName(os.Args[2:])
+
+	var sm *service.ServiceManager
+	if scope == service.UserScope {
+		sm = service.NewUserServiceManager(name, serviceDesc)
+	} else {
+		sm = service.NewServiceManager(name, serviceDesc)
+	}
 
 	switch command {
 	case "start":
@@ -69,8 +94,10 @@ func main() {
 		fmt.Println("Service uninstalled successfully")
 
 	case "run":
-		// This is called by systemd to actually run the service
-		runService()
+		// This is called by systemd (or the other backends' equivalent) to
+		// actually run the service. name is the instance it was installed
+		// as, parsed back out of the generated ExecStart/command/args above.
+		runService(name)
 
 	default:
 		fmt.Printf("Unknown command: %s\n", command)
@@ -80,54 +107,227 @@ func main() {
 }
 
 func printUsage() {
-	fmt.Printf(`Usage: %s <command>
+	fmt.Printf(`Usage: %s <command> [name] [--user]
 
 Commands:
   start      Start the service
   stop       Stop the service
   restart    Restart the service
   status     Check service status
-  install    Install the service (requires sudo)
-  uninstall  Uninstall the service (requires sudo)
+  install    Install the service (requires sudo, unless --user is given)
+  uninstall  Uninstall the service (requires sudo, unless --user is given)
+  list       List every service this tool manages
+  logs       Stream the service's logs (journalctl -u <name>.service)
   run        Run the service (used by systemd)
 
+Flags:
+  --user     Manage a per-user service instead of a system-wide one (no sudo needed)
+
+Logs flags:
+  --follow, -f          Follow the log as it grows
+  --lines, -n N         Show the last N lines
+  --since "YYYY-MM-DD HH:MM:SS"
+  --until "YYYY-MM-DD HH:MM:SS"
+  --priority, -p LEVEL  Filter by syslog priority (e.g. err, warning)
+  --output, -o FORMAT   journalctl output format (e.g. json)
+
+An optional [name] lets one binary manage several named instances of
+itself, e.g. "install worker-a" and "install worker-b".
+
 Example:
   sudo %s install
   sudo %s start
   %s status
   sudo %s stop
   sudo %s uninstall
-`, os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0])
+  %s list
+
+  %s install --user
+  %s start --user
+  sudo %s install worker-a
+`, os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0])
 }
 
-func runService() {
-	fmt.Println("Service is starting...")
+// hasUserFlag reports whether --user was passed among the trailing args.
+func hasUserFlag(args []string) bool {
+	for _, arg := range args {
+		if arg == "--user" {
+			return true
+		}
+	}
+	return false
+}
 
-	// Set up signal handling for graceful shutdown
-	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+// instanceName returns the first non-flag argument, e.g. the "foo" in
+// `install foo`, falling back to the default instance name. This lets one
+// binary install/manage several named instances of itself.
+func instanceName(args []string) string {
+	for _, arg := range args {
+		if !strings.HasPrefix(arg, "--") {
+			return arg
+		}
+	}
+	return serviceName
+}
 
-	// Create a ticker for periodic work (example: every 30 seconds)
-	ticker := time.NewTicker(30 * time.Second)
-	defer ticker.Stop()
+// runList prints every service this tool manages, in the style of
+// `serviceman list --all`.
+func runList(args []string) {
+	scope := service.SystemScope
+	if hasUserFlag(args) {
+		scope = service.UserScope
+	}
 
-	fmt.Println("Service is running... (Press Ctrl+C to stop)")
+	infos, err := service.NewRegistry(scope).List()
+	if err != nil {
+		fmt.Printf("Error listing services: %v\n", err)
+		os.Exit(1)
+	}
 
-	for {
-		select {
-		case <-ticker.C:
-			// Do periodic work here
-			fmt.Printf("[%s] Service is running and doing work...\n", time.Now().Format("2006-01-02 15:04:05"))
+	if len(infos) == 0 {
+		fmt.Println("No managed services found")
+		return
+	}
 
-		case sig := <-sigChan:
-			fmt.Printf("Received signal: %s. Shutting down gracefully...\n", sig)
+	fmt.Printf("%-20s %-30s %-8s %-8s %s\n", "NAME", "DESCRIPTION", "ACTIVE", "ENABLED", "PID")
+	for _, info := range infos {
+		pid := "-"
+		if info.PID != 0 {
+			pid = fmt.Sprintf("%d", info.PID)
+		}
+		fmt.Printf("%-20s %-30s %-8t %-8t %s\n", info.Name, info.Description, info.Active, info.Enabled, pid)
+	}
+}
+
+// runLogs streams the service's logs to stdout. It parses args itself
+// instead of going through hasUserFlag/instanceName, since those only know
+// about bare "--xxx" flags and would otherwise misparse logs' single-dash
+// and value-consuming flags (e.g. reading "50" out of "--lines 50" as the
+// instance name). Recognizes --follow/-f, --lines/-n N, --since "...",
+// --until "...", --priority/-p LEVEL, --output/-o FORMAT (e.g. json), and
+// --user, plus an optional instance name.
+func runLogs(args []string) {
+	opts := service.LogOptions{}
+	scope := service.SystemScope
+	name := serviceName
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--follow", "-f":
+			opts.Follow = true
+		case "--lines", "-n":
+			i++
+			if i < len(args) {
+				if n, err := strconv.Atoi(args[i]); err == nil {
+					opts.Lines = n
+				}
+			}
+		case "--since":
+			i++
+			if i < len(args) {
+				if t, err := time.Parse("2006-01-02 15:04:05", args[i]); err == nil {
+					opts.Since = t
+				}
+			}
+		case "--until":
+			i++
+			if i < len(args) {
+				if t, err := time.Parse("2006-01-02 15:04:05", args[i]); err == nil {
+					opts.Until = t
+				}
+			}
+		case "--priority", "-p":
+			i++
+			if i < len(args) {
+				opts.Priority = args[i]
+			}
+		case "--output", "-o":
+			i++
+			if i < len(args) {
+				opts.Output = args[i]
+			}
+		case "--user":
+			scope = service.UserScope
+		default:
+			if !strings.HasPrefix(args[i], "-") {
+				name = args[i]
+			}
+		}
+	}
+
+	var sm *service.ServiceManager
+	if scope == service.UserScope {
+		sm = service.NewUserServiceManager(name, serviceDesc)
+	} else {
+		sm = service.NewServiceManager(name, serviceDesc)
+	}
 
-			// Perform cleanup here
-			cleanup()
+	rc, err := sm.Logs(opts)
+	if err != nil {
+		fmt.Printf("Error fetching logs: %v\n", err)
+		os.Exit(1)
+	}
+	defer rc.Close()
 
-			fmt.Println("Service stopped.")
+	if opts.Output != "json" {
+		if _, err := io.Copy(os.Stdout, rc); err != nil {
+			fmt.Printf("Error streaming logs: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Decode rather than just copying through, so entries are available as
+	// JournalEntry values (e.g. for a future --filter flag) instead of only
+	// ever being printed verbatim. A single malformed line (possible with
+	// --follow, e.g. journalctl writing a non-JSON notice to stdout) is
+	// logged and skipped rather than aborting the whole stream.
+	dec := service.NewJournalDecoder(rc)
+	for {
+		entry, err := dec.Next()
+		if err == io.EOF {
 			return
 		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: skipping unparseable journal entry: %v\n", err)
+			continue
+		}
+		fmt.Printf("[%s] %s: %s\n", entry.RealtimeTimestamp, entry.PID, entry.Message)
+	}
+}
+
+func runService(name string) {
+	fmt.Println("Service is starting...")
+
+	// If the unit has WatchdogSec= set, systemd expects a WATCHDOG=1 ping
+	// at roughly half that interval or it'll consider us hung and restart
+	// us. StartWatchdog no-ops when WATCHDOG_USEC isn't set.
+	watchdogCtx, stopWatchdog := context.WithCancel(context.Background())
+	defer stopWatchdog()
+	service.StartWatchdog(watchdogCtx)
+
+	// Tell systemd we're done starting up (only has an effect on a
+	// Type=notify unit; otherwise this is a no-op).
+	if err := service.NotifyReady(); err != nil {
+		fmt.Printf("Warning: failed to notify systemd readiness: %v\n", err)
+	}
+
+	fmt.Println("Service is running... (Press Ctrl+C to stop)")
+
+	err := service.RunLoop(name, 30*time.Second, func() {
+		// Do periodic work here
+		now := time.Now()
+		fmt.Printf("[%s] Service is running and doing work...\n", now.Format("2006-01-02 15:04:05"))
+		service.NotifyStatus(fmt.Sprintf("running, last tick %s", now.Format(time.RFC3339)))
+	}, func() {
+		service.NotifyStopping()
+		fmt.Println("Shutting down gracefully...")
+		cleanup()
+		fmt.Println("Service stopped.")
+	})
+	if err != nil {
+		fmt.Printf("Service run loop failed: %v\n", err)
+		os.Exit(1)
 	}
 }
 
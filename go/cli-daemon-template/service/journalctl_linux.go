@@ -0,0 +1,72 @@
+//go:build linux
+
+package service
+
+import (
+	"fmt"
+	"io"
+	"os/exec"
+	"strconv"
+)
+
+// Logs streams this unit's log output from journalctl, honoring opts. The
+// generated unit already routes StandardOutput=journal with
+// SyslogIdentifier={{.Name}}, so `-u <name>.service` picks it up directly.
+func (sm *systemdService) Logs(opts LogOptions) (io.ReadCloser, error) {
+	args := []string{}
+	if sm.Scope == UserScope {
+		args = append(args, "--user")
+	}
+	args = append(args, "-u", sm.Name+".service")
+
+	if opts.Follow {
+		args = append(args, "-f")
+	}
+	if opts.Lines > 0 {
+		args = append(args, "-n", strconv.Itoa(opts.Lines))
+	}
+	if !opts.Since.IsZero() {
+		args = append(args, "--since", opts.Since.Format("2006-01-02 15:04:05"))
+	}
+	if !opts.Until.IsZero() {
+		args = append(args, "--until", opts.Until.Format("2006-01-02 15:04:05"))
+	}
+	if opts.Priority != "" {
+		args = append(args, "-p", opts.Priority)
+	}
+	if opts.Output != "" {
+		args = append(args, "-o", opts.Output)
+	}
+
+	cmd := exec.Command("journalctl", args...)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open journalctl stdout: %w", err)
+	}
+
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start journalctl: %w", err)
+	}
+
+	return &journalReader{cmd: cmd, stdout: stdout}, nil
+}
+
+// journalReader wraps a running journalctl process so callers can Close it
+// (killing `journalctl -f` instead of leaking it) like any other
+// io.ReadCloser.
+type journalReader struct {
+	cmd    *exec.Cmd
+	stdout io.ReadCloser
+}
+
+func (r *journalReader) Read(p []byte) (int, error) {
+	return r.stdout.Read(p)
+}
+
+func (r *journalReader) Close() error {
+	r.stdout.Close()
+	if r.cmd.Process != nil {
+		r.cmd.Process.Kill()
+	}
+	return r.cmd.Wait()
+}
@@ -0,0 +1,205 @@
+//go:build darwin
+
+package service
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// launchdService manages a service via a launchd property list and
+// launchctl, for macOS.
+type launchdService struct {
+	Name        string
+	Description string
+	Label       string
+	PlistPath   string
+	BinaryPath  string
+	Scope       Scope
+	Options     UnitOptions
+}
+
+func newLaunchdService(name, description string, scope Scope, opts UnitOptions) *launchdService {
+	execPath, _ := os.Executable()
+	label := fmt.Sprintf("com.cli-daemon-template.%s", name)
+	return &launchdService{
+		Name:        name,
+		Description: description,
+		Label:       label,
+		PlistPath:   plistPath(label, scope),
+		BinaryPath:  execPath,
+		Scope:       scope,
+		Options:     opts,
+	}
+}
+
+// plistPath returns the property list location for the given scope:
+// /Library/LaunchDaemons for the system instance, ~/Library/LaunchAgents
+// for the per-user instance.
+func plistPath(label string, scope Scope) string {
+	if scope == UserScope {
+		home, _ := os.UserHomeDir()
+		return filepath.Join(home, "Library", "LaunchAgents", label+".plist")
+	}
+	return fmt.Sprintf("/Library/LaunchDaemons/%s.plist", label)
+}
+
+// Start starts the service.
+func (sm *launchdService) Start() error {
+	return sm.launchctl("start", sm.Label)
+}
+
+// Stop stops the service.
+func (sm *launchdService) Stop() error {
+	return sm.launchctl("stop", sm.Label)
+}
+
+// Restart restarts the service.
+func (sm *launchdService) Restart() error {
+	if err := sm.Stop(); err != nil {
+		return err
+	}
+	return sm.Start()
+}
+
+// Status returns the service status.
+func (sm *launchdService) Status() (string, error) {
+	cmd := exec.Command("launchctl", "list", sm.Label)
+	output, err := cmd.Output()
+	if err != nil {
+		return "inactive", nil
+	}
+	if strings.Contains(string(output), "\"PID\"") {
+		return "active", nil
+	}
+	return "inactive", nil
+}
+
+// Install installs the service by writing the launchd plist and loading it.
+func (sm *launchdService) Install() error {
+	if sm.Scope == SystemScope && os.Geteuid() != 0 {
+		return fmt.Errorf("installation requires root privileges. Run with sudo")
+	}
+
+	if sm.Scope == UserScope {
+		if err := os.MkdirAll(filepath.Dir(sm.PlistPath), 0755); err != nil {
+			return fmt.Errorf("failed to create LaunchAgents directory: %w", err)
+		}
+	}
+
+	if err := os.WriteFile(sm.PlistPath, []byte(sm.generatePlist()), 0644); err != nil {
+		return fmt.Errorf("failed to create plist file: %w", err)
+	}
+
+	if err := sm.launchctl("load", "-w", sm.PlistPath); err != nil {
+		return fmt.Errorf("failed to load launchd job: %w", err)
+	}
+
+	return nil
+}
+
+// Uninstall removes the service.
+func (sm *launchdService) Uninstall() error {
+	if sm.Scope == SystemScope && os.Geteuid() != 0 {
+		return fmt.Errorf("uninstallation requires root privileges. Run with sudo")
+	}
+
+	sm.launchctl("unload", "-w", sm.PlistPath)
+
+	if err := os.Remove(sm.PlistPath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove plist file: %w", err)
+	}
+
+	return nil
+}
+
+// IsInstalled checks if the service is installed.
+func (sm *launchdService) IsInstalled() bool {
+	_, err := os.Stat(sm.PlistPath)
+	return err == nil
+}
+
+// SetBinaryPath overrides the binary the plist's ProgramArguments launches.
+func (sm *launchdService) SetBinaryPath(path string) {
+	sm.BinaryPath, _ = filepath.Abs(path)
+}
+
+// GetBinaryPath returns the binary the plist's ProgramArguments launches.
+func (sm *launchdService) GetBinaryPath() string {
+	return sm.BinaryPath
+}
+
+// GetServiceFile returns the path to the plist.
+func (sm *launchdService) GetServiceFile() string {
+	return sm.PlistPath
+}
+
+func (sm *launchdService) launchctl(args ...string) error {
+	cmd := exec.Command("launchctl", args...)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("launchctl %s failed: %w\nOutput: %s", strings.Join(args, " "), err, string(output))
+	}
+	return nil
+}
+
+// generatePlist creates the launchd property list content. Only the fields
+// that map naturally onto a plist (ExecArgs, WorkingDirectory, Environment)
+// are applied from Options; systemd-only knobs like Restart/After have no
+// launchd equivalent.
+func (sm *launchdService) generatePlist() string {
+	var args strings.Builder
+	fmt.Fprintf(&args, "\t\t<string>%s</string>\n\t\t<string>run</string>\n\t\t<string>%s</string>\n", plistEscape(sm.BinaryPath), plistEscape(sm.Name))
+	for _, arg := range sm.Options.ExecArgs {
+		fmt.Fprintf(&args, "\t\t<string>%s</string>\n", plistEscape(arg))
+	}
+
+	var workingDir strings.Builder
+	if sm.Options.WorkingDirectory != "" {
+		fmt.Fprintf(&workingDir, "\t<key>WorkingDirectory</key>\n\t<string>%s</string>\n", plistEscape(sm.Options.WorkingDirectory))
+	}
+
+	var env strings.Builder
+	if len(sm.Options.Environment) > 0 {
+		env.WriteString("\t<key>EnvironmentVariables</key>\n\t<dict>\n")
+		for key, value := range sm.Options.Environment {
+			fmt.Fprintf(&env, "\t\t<key>%s</key>\n\t\t<string>%s</string>\n", plistEscape(key), plistEscape(value))
+		}
+		env.WriteString("\t</dict>\n")
+	}
+
+	return fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<key>Label</key>
+	<string>%s</string>
+	<key>ProgramArguments</key>
+	<array>
+%s	</array>
+%s%s	<key>RunAtLoad</key>
+	<true/>
+	<key>KeepAlive</key>
+	<true/>
+	<key>StandardOutPath</key>
+	<string>/var/log/%s.log</string>
+	<key>StandardErrorPath</key>
+	<string>/var/log/%s.log</string>
+</dict>
+</plist>
+`, plistEscape(sm.Label), args.String(), workingDir.String(), env.String(), plistEscape(sm.Name), plistEscape(sm.Name))
+}
+
+// plistEscape escapes s for use as plist XML text content (e.g. `&`, `<`,
+// `>` in an Environment value), so a value like "http://x?a=1&b=2" doesn't
+// produce a malformed plist that launchd refuses to load entirely.
+func plistEscape(s string) string {
+	var buf bytes.Buffer
+	xml.EscapeText(&buf, []byte(s))
+	return buf.String()
+}
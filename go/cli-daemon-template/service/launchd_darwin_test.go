@@ -0,0 +1,59 @@
+//go:build darwin
+
+package service
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLaunchdGeneratePlist(t *testing.T) {
+	sm := newLaunchdService("test-service", "Test Description", SystemScope, UnitOptions{})
+	sm.BinaryPath = "/usr/local/bin/test-service"
+
+	content := sm.generatePlist()
+
+	expected := []string{
+		"<?xml version=\"1.0\" encoding=\"UTF-8\"?>",
+		"<key>Label</key>",
+		"<string>com.cli-daemon-template.test-service</string>",
+		"<string>/usr/local/bin/test-service</string>",
+		"<string>run</string>",
+		"<string>test-service</string>",
+	}
+	for _, want := range expected {
+		if !strings.Contains(content, want) {
+			t.Errorf("Plist content missing expected line %q, got:\n%s", want, content)
+		}
+	}
+}
+
+func TestLaunchdGeneratePlistEscapesXML(t *testing.T) {
+	sm := newLaunchdService("test-service", "Test Description", SystemScope, UnitOptions{
+		WorkingDirectory: "/srv/a&b",
+		Environment:      map[string]string{"URL": "http://x?a=1&b=2"},
+	})
+
+	content := sm.generatePlist()
+
+	if strings.Contains(content, "a&b") {
+		t.Error("Expected WorkingDirectory/Environment values with & to be escaped")
+	}
+	if !strings.Contains(content, "http://x?a=1&amp;b=2") {
+		t.Errorf("Expected escaped Environment value, got:\n%s", content)
+	}
+	if !strings.Contains(content, "/srv/a&amp;b") {
+		t.Errorf("Expected escaped WorkingDirectory value, got:\n%s", content)
+	}
+}
+
+func TestLaunchdSetBinaryPath(t *testing.T) {
+	sm := newLaunchdService("test-service", "Test Description", SystemScope, UnitOptions{})
+
+	testPath := "/custom/path/to/binary"
+	sm.SetBinaryPath(testPath)
+
+	if !strings.HasSuffix(sm.GetBinaryPath(), testPath) {
+		t.Errorf("Expected binary path to end with %s, got %s", testPath, sm.GetBinaryPath())
+	}
+}
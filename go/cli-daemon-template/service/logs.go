@@ -0,0 +1,115 @@
+package service
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// LogOptions controls how ServiceManager.Logs filters and streams log
+// output. A zero value streams the full, non-following history.
+type LogOptions struct {
+	Follow   bool
+	Lines    int
+	Since    time.Time
+	Until    time.Time
+	Priority string
+	Output   string
+}
+
+// JournalEntry is one line of `journalctl -o json` output, for callers that
+// want to filter or process log lines programmatically rather than just
+// printing them.
+type JournalEntry struct {
+	RealtimeTimestamp string `json:"__REALTIME_TIMESTAMP"`
+	Message           string `json:"MESSAGE"`
+	Priority          string `json:"PRIORITY"`
+	PID               string `json:"_PID"`
+}
+
+// JournalDecoder reads `journalctl -o json` output (one JSON object per
+// line) and decodes it into JournalEntry values, so a caller with
+// LogOptions.Output == "json" can filter or process entries instead of
+// just printing raw journalctl output.
+type JournalDecoder struct {
+	scanner *bufio.Scanner
+	done    bool
+}
+
+// maxJournalLine raises the scanner past bufio's default 64KB limit, since a
+// single journal entry (e.g. a long MESSAGE) can exceed that and the
+// default would otherwise fail the whole stream with bufio.ErrTooLong.
+const maxJournalLine = 1024 * 1024
+
+// NewJournalDecoder wraps r, typically the ReadCloser returned by
+// ServiceManager.Logs with Output: "json".
+func NewJournalDecoder(r io.Reader) *JournalDecoder {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxJournalLine)
+	return &JournalDecoder{scanner: scanner}
+}
+
+// Next decodes the next journal entry, returning io.EOF once the underlying
+// reader is exhausted or a scanner-level error (e.g. a line past
+// maxJournalLine) has ended the stream for good; any such error is reported
+// exactly once, on the call that encounters it, not on every call after.
+func (d *JournalDecoder) Next() (JournalEntry, error) {
+	if d.done {
+		return JournalEntry{}, io.EOF
+	}
+	for d.scanner.Scan() {
+		line := d.scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var entry JournalEntry
+		if err := json.Unmarshal(line, &entry); err != nil {
+			return JournalEntry{}, fmt.Errorf("failed to parse journal entry: %w", err)
+		}
+		return entry, nil
+	}
+	d.done = true
+	if err := d.scanner.Err(); err != nil {
+		return JournalEntry{}, err
+	}
+	return JournalEntry{}, io.EOF
+}
+
+// ParseJournalEntries reads r to completion and decodes every line into a
+// JournalEntry. r is typically the ReadCloser returned by
+// ServiceManager.Logs with Output: "json" and Follow: false; for a
+// follow-mode stream, use NewJournalDecoder and call Next in a loop
+// instead.
+func ParseJournalEntries(r io.Reader) ([]JournalEntry, error) {
+	dec := NewJournalDecoder(r)
+	var entries []JournalEntry
+	for {
+		entry, err := dec.Next()
+		if err == io.EOF {
+			return entries, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+}
+
+// LogSource is implemented by backends that can stream logs for their
+// service. Not every backend can (there's no journalctl equivalent wired up
+// for launchd/OpenRC/Windows yet), so ServiceManager.Logs checks for it at
+// runtime instead of requiring it on Service.
+type LogSource interface {
+	Logs(opts LogOptions) (io.ReadCloser, error)
+}
+
+// Logs streams the service's log output, e.g. from journalctl on systemd.
+func (sm *ServiceManager) Logs(opts LogOptions) (io.ReadCloser, error) {
+	ls, ok := sm.backend.(LogSource)
+	if !ok {
+		return nil, fmt.Errorf("log streaming is not supported for this service backend")
+	}
+	return ls.Logs(opts)
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+// stubBackend implements Service but not LogSource, standing in for a
+// backend (e.g. launchd, Windows SCM) that doesn't support log streaming.
+type stubBackend struct{}
+
+func (stubBackend) Start() error            { return nil }
+func (stubBackend) Stop() error             { return nil }
+func (stubBackend) Restart() error          { return nil }
+func (stubBackend) Status() (string, error) { return "unknown", nil }
+func (stubBackend) Install() error          { return nil }
+func (stubBackend) Uninstall() error        { return nil }
+func (stubBackend) IsInstalled() bool       { return false }
+
+func TestLogsUnsupportedOnBackendWithoutLogSource(t *testing.T) {
+	sm := &ServiceManager{Name: "test-service", backend: stubBackend{}}
+
+	if _, err := sm.Logs(LogOptions{}); err == nil {
+		t.Error("expected Logs to fail for a backend that doesn't implement LogSource")
+	}
+}
+
+func TestParseJournalEntries(t *testing.T) {
+	input := `{"__REALTIME_TIMESTAMP":"1000000","MESSAGE":"starting up","PRIORITY":"6","_PID":"123"}
+{"__REALTIME_TIMESTAMP":"1000001","MESSAGE":"ready","PRIORITY":"6","_PID":"123"}
+`
+	entries, err := ParseJournalEntries(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseJournalEntries returned error: %v", err)
+	}
+
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(entries))
+	}
+	if entries[0].Message != "starting up" || entries[0].PID != "123" {
+		t.Errorf("unexpected first entry: %+v", entries[0])
+	}
+	if entries[1].Message != "ready" {
+		t.Errorf("unexpected second entry: %+v", entries[1])
+	}
+}
+
+func TestParseJournalEntriesInvalidJSON(t *testing.T) {
+	if _, err := ParseJournalEntries(strings.NewReader("not json\n")); err == nil {
+		t.Error("expected ParseJournalEntries to fail on malformed input")
+	}
+}
+
+func TestJournalDecoderSkipsBlankLines(t *testing.T) {
+	input := "\n{\"MESSAGE\":\"hello\"}\n\n"
+	dec := NewJournalDecoder(strings.NewReader(input))
+
+	entry, err := dec.Next()
+	if err != nil {
+		t.Fatalf("Next returned error: %v", err)
+	}
+	if entry.Message != "hello" {
+		t.Errorf("expected message %q, got %q", "hello", entry.Message)
+	}
+
+	if _, err := dec.Next(); err != io.EOF {
+		t.Errorf("expected io.EOF after the only entry, got: %v", err)
+	}
+}
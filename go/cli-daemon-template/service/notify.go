@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"net"
+	"os"
+	"strconv"
+	"time"
+)
+
+// sdNotify sends a systemd notify-protocol message over NOTIFY_SOCKET. It's
+// a no-op (returning nil) when NOTIFY_SOCKET isn't set, which is the case
+// unless the unit is Type=notify and was started by systemd — so it's safe
+// to call unconditionally, including on platforms without systemd.
+func sdNotify(state string) error {
+	socket := os.Getenv("NOTIFY_SOCKET")
+	if socket == "" {
+		return nil
+	}
+
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: socket, Net: "unixgram"})
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	_, err = conn.Write([]byte(state))
+	return err
+}
+
+// NotifyReady tells systemd the service has finished starting up. Pairs
+// with Type=notify on the generated unit (UnitOptions.Notify).
+func NotifyReady() error {
+	return sdNotify("READY=1")
+}
+
+// NotifyStatus sends a free-form status string, shown by `systemctl status`.
+func NotifyStatus(msg string) error {
+	return sdNotify("STATUS=" + msg)
+}
+
+// NotifyStopping tells systemd the service is beginning to shut down.
+func NotifyStopping() error {
+	return sdNotify("STOPPING=1")
+}
+
+// StartWatchdog pings systemd at half the interval systemd gave us via
+// WATCHDOG_USEC (set when the unit has WatchdogSec= configured), until ctx
+// is canceled. If WATCHDOG_USEC isn't set, it does nothing.
+func StartWatchdog(ctx context.Context) {
+	usec, err := strconv.Atoi(os.Getenv("WATCHDOG_USEC"))
+	if err != nil || usec <= 0 {
+		return
+	}
+
+	interval := time.Duration(usec/2) * time.Microsecond
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				sdNotify("WATCHDOG=1")
+			}
+		}
+	}()
+}
@@ -0,0 +1,32 @@
+package service
+
+import (
+	"context"
+	"os"
+	"testing"
+)
+
+func TestNotifyNoOpsWithoutNotifySocket(t *testing.T) {
+	os.Unsetenv("NOTIFY_SOCKET")
+
+	if err := NotifyReady(); err != nil {
+		t.Errorf("expected NotifyReady to no-op without NOTIFY_SOCKET, got: %v", err)
+	}
+	if err := NotifyStatus("working"); err != nil {
+		t.Errorf("expected NotifyStatus to no-op without NOTIFY_SOCKET, got: %v", err)
+	}
+	if err := NotifyStopping(); err != nil {
+		t.Errorf("expected NotifyStopping to no-op without NOTIFY_SOCKET, got: %v", err)
+	}
+}
+
+func TestStartWatchdogNoOpsWithoutWatchdogUsec(t *testing.T) {
+	os.Unsetenv("WATCHDOG_USEC")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Should return immediately without spawning a ticking goroutine;
+	// there's nothing observable to assert beyond "doesn't panic or hang".
+	StartWatchdog(ctx)
+}
@@ -0,0 +1,174 @@
+//go:build linux
+
+package service
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// openrcService manages a service via an OpenRC init script and
+// rc-service/rc-update, for Alpine/Gentoo systems that don't run systemd.
+type openrcService struct {
+	Name        string
+	Description string
+	InitScript  string
+	BinaryPath  string
+	Options     UnitOptions
+}
+
+func newOpenRCService(name, description string, opts UnitOptions) *openrcService {
+	execPath, _ := os.Executable()
+	return &openrcService{
+		Name:        name,
+		Description: description,
+		InitScript:  fmt.Sprintf("/etc/init.d/%s", name),
+		BinaryPath:  execPath,
+		Options:     opts,
+	}
+}
+
+// Start starts the service.
+func (sm *openrcService) Start() error {
+	return sm.rcService("start")
+}
+
+// Stop stops the service.
+func (sm *openrcService) Stop() error {
+	return sm.rcService("stop")
+}
+
+// Restart restarts the service.
+func (sm *openrcService) Restart() error {
+	return sm.rcService("restart")
+}
+
+// Status returns the service status.
+func (sm *openrcService) Status() (string, error) {
+	cmd := exec.Command("rc-service", sm.Name, "status")
+	output, err := cmd.CombinedOutput()
+	status := strings.TrimSpace(string(output))
+	if err != nil {
+		if strings.Contains(status, "stopped") {
+			return "inactive", nil
+		}
+		return "unknown", err
+	}
+	if strings.Contains(status, "started") {
+		return "active", nil
+	}
+	return status, nil
+}
+
+// Install installs the service by creating the OpenRC init script.
+func (sm *openrcService) Install() error {
+	if os.Geteuid() != 0 {
+		return fmt.Errorf("installation requires root privileges. Run with sudo")
+	}
+
+	if err := os.WriteFile(sm.InitScript, []byte(sm.generateInitScript()), 0755); err != nil {
+		return fmt.Errorf("failed to create init script: %w", err)
+	}
+
+	if err := sm.rcUpdate("add"); err != nil {
+		return fmt.Errorf("failed to enable service: %w", err)
+	}
+
+	return nil
+}
+
+// Uninstall removes the service.
+func (sm *openrcService) Uninstall() error {
+	if os.Geteuid() != 0 {
+		return fmt.Errorf("uninstallation requires root privileges. Run with sudo")
+	}
+
+	sm.Stop()
+	sm.rcUpdate("del")
+
+	if err := os.Remove(sm.InitScript); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove init script: %w", err)
+	}
+
+	return nil
+}
+
+// IsInstalled checks if the service is installed.
+func (sm *openrcService) IsInstalled() bool {
+	_, err := os.Stat(sm.InitScript)
+	return err == nil
+}
+
+// SetBinaryPath overrides the binary the init script launches.
+func (sm *openrcService) SetBinaryPath(path string) {
+	sm.BinaryPath, _ = filepath.Abs(path)
+}
+
+// GetBinaryPath returns the binary the init script launches.
+func (sm *openrcService) GetBinaryPath() string {
+	return sm.BinaryPath
+}
+
+// GetServiceFile returns the path to the init script.
+func (sm *openrcService) GetServiceFile() string {
+	return sm.InitScript
+}
+
+func (sm *openrcService) rcService(action string) error {
+	cmd := exec.Command("rc-service", sm.Name, action)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("rc-service %s %s failed: %w\nOutput: %s", sm.Name, action, err, string(output))
+	}
+	return nil
+}
+
+func (sm *openrcService) rcUpdate(action string) error {
+	cmd := exec.Command("rc-update", action, sm.Name, "default")
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("rc-update %s %s failed: %w\nOutput: %s", action, sm.Name, err, string(output))
+	}
+	return nil
+}
+
+// generateInitScript creates the OpenRC init script content. Only the
+// fields OpenRC has a natural equivalent for (ExecArgs, User, Group,
+// WorkingDirectory) are applied from Options; Group is only written together
+// with User, as part of command_user. The rest (systemd-specific knobs like
+// Restart/After/LimitNOFILE) don't have an OpenRC analogue.
+func (sm *openrcService) generateInitScript() string {
+	commandArgs := "run " + sm.Name
+	for _, arg := range sm.Options.ExecArgs {
+		commandArgs += " " + arg
+	}
+
+	var extra strings.Builder
+	if sm.Options.User != "" {
+		fmt.Fprintf(&extra, "command_user=\"%s", sm.Options.User)
+		if sm.Options.Group != "" {
+			fmt.Fprintf(&extra, ":%s", sm.Options.Group)
+		}
+		extra.WriteString("\"\n")
+	}
+	if sm.Options.WorkingDirectory != "" {
+		fmt.Fprintf(&extra, "directory=\"%s\"\n", sm.Options.WorkingDirectory)
+	}
+
+	return fmt.Sprintf(`#!/sbin/openrc-run
+
+name="%s"
+description="%s"
+command="%s"
+command_args="%s"
+command_background="yes"
+pidfile="/run/${RC_SVCNAME}.pid"
+%s
+depend() {
+	need net
+}
+`, sm.Name, sm.Description, sm.BinaryPath, commandArgs, extra.String())
+}
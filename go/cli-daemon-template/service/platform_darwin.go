@@ -0,0 +1,8 @@
+//go:build darwin
+
+package service
+
+// newBackend returns the launchd backend, the only one macOS supports.
+func newBackend(name, description string, scope Scope, opts UnitOptions) Service {
+	return newLaunchdService(name, description, scope, opts)
+}
@@ -0,0 +1,33 @@
+//go:build linux
+
+package service
+
+import (
+	"os"
+	"strings"
+)
+
+// newBackend picks the init system actually running on this box. Most
+// distros run systemd, but Alpine and some Gentoo setups run OpenRC instead,
+// so we probe for systemd the same way kardianos/service does before
+// falling back to OpenRC.
+func newBackend(name, description string, scope Scope, opts UnitOptions) Service {
+	if usesSystemd() {
+		return newSystemdService(name, description, scope, opts)
+	}
+	// OpenRC has no user-service concept; it always manages system-wide
+	// init scripts.
+	return newOpenRCService(name, description, opts)
+}
+
+func usesSystemd() bool {
+	if _, err := os.Stat("/run/systemd/system"); err == nil {
+		return true
+	}
+
+	comm, err := os.ReadFile("/proc/1/comm")
+	if err != nil {
+		return false
+	}
+	return strings.TrimSpace(string(comm)) == "systemd"
+}
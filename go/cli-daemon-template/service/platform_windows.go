@@ -0,0 +1,9 @@
+//go:build windows
+
+package service
+
+// newBackend returns the Windows SCM backend, the only one Windows supports.
+// The Windows SCM has no notion of a per-user service, so scope is ignored.
+func newBackend(name, description string, scope Scope, opts UnitOptions) Service {
+	return newWindowsService(name, description, opts)
+}
@@ -0,0 +1,35 @@
+package service
+
+import "fmt"
+
+// ServiceInfo describes one service discovered by a Registry, combining the
+// unit file on disk with its live status.
+type ServiceInfo struct {
+	Name        string
+	Description string
+	Active      bool
+	Enabled     bool
+	PID         int
+	Manager     *ServiceManager
+}
+
+// Registry discovers services that this tool installed, scoped to either
+// the system-wide or per-user instance.
+type Registry struct {
+	Scope Scope
+}
+
+// NewRegistry creates a Registry for the given scope.
+func NewRegistry(scope Scope) *Registry {
+	return &Registry{Scope: scope}
+}
+
+// List returns every service this tool owns (identified by managedByMarker
+// in the generated unit file), along with its live status.
+func (r *Registry) List() ([]ServiceInfo, error) {
+	infos, err := listManaged(r.Scope)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list managed services: %w", err)
+	}
+	return infos, nil
+}
@@ -0,0 +1,156 @@
+//go:build linux
+
+package service
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// unitListEntry mirrors one element of `systemctl list-units --output=json`.
+type unitListEntry struct {
+	Unit        string `json:"unit"`
+	Load        string `json:"load"`
+	Active      string `json:"active"`
+	Sub         string `json:"sub"`
+	Description string `json:"description"`
+}
+
+// listManaged scans the unit directory for the given scope, keeps the ones
+// carrying managedByMarker, and fills in their active state from a single
+// `systemctl list-units --all --output=json` call. The enabled state and
+// main PID are looked up with one further systemctl call per unit.
+func listManaged(scope Scope) ([]ServiceInfo, error) {
+	dir := unitDir(scope)
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", dir, err)
+	}
+
+	statuses, err := listUnitStatuses(scope)
+	if err != nil {
+		return nil, err
+	}
+
+	var infos []ServiceInfo
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".service") {
+			continue
+		}
+
+		path := filepath.Join(dir, entry.Name())
+		description, owned := readManagedUnit(path)
+		if !owned {
+			continue
+		}
+
+		name := strings.TrimSuffix(entry.Name(), ".service")
+		status := statuses[entry.Name()]
+
+		infos = append(infos, ServiceInfo{
+			Name:        name,
+			Description: description,
+			Active:      status.Active == "active",
+			Enabled:     isEnabled(name, scope),
+			PID:         mainPID(name, scope, status.Active == "active"),
+			Manager:     newServiceManager(name, description, scope),
+		})
+	}
+
+	return infos, nil
+}
+
+// readManagedUnit reads a unit file and, if it carries managedByMarker,
+// returns its Description= value and true.
+func readManagedUnit(path string) (description string, owned bool) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", false
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == managedByMarker {
+			owned = true
+		}
+		if strings.HasPrefix(line, "Description=") {
+			description = strings.TrimPrefix(line, "Description=")
+		}
+	}
+
+	return description, owned
+}
+
+// listUnitStatuses runs a single `systemctl list-units` call and returns the
+// result keyed by unit name (e.g. "foo.service").
+func listUnitStatuses(scope Scope) (map[string]unitListEntry, error) {
+	args := []string{}
+	if scope == UserScope {
+		args = append(args, "--user")
+	}
+	args = append(args, "list-units", "--all", "--output=json", "--no-legend")
+
+	output, err := exec.Command("systemctl", args...).Output()
+	if err != nil {
+		return nil, fmt.Errorf("systemctl list-units failed: %w", err)
+	}
+
+	var entries []unitListEntry
+	if err := json.Unmarshal(output, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse systemctl list-units output: %w", err)
+	}
+
+	statuses := make(map[string]unitListEntry, len(entries))
+	for _, entry := range entries {
+		statuses[entry.Unit] = entry
+	}
+	return statuses, nil
+}
+
+// isEnabled reports whether the unit is enabled to start on boot.
+func isEnabled(name string, scope Scope) bool {
+	args := []string{}
+	if scope == UserScope {
+		args = append(args, "--user")
+	}
+	args = append(args, "is-enabled", name)
+
+	output, err := exec.Command("systemctl", args...).Output()
+	if err != nil {
+		return false
+	}
+	return strings.TrimSpace(string(output)) == "enabled"
+}
+
+// mainPID looks up the running process ID for an active unit.
+func mainPID(name string, scope Scope, active bool) int {
+	if !active {
+		return 0
+	}
+
+	args := []string{}
+	if scope == UserScope {
+		args = append(args, "--user")
+	}
+	args = append(args, "show", name, "--property=MainPID", "--value")
+
+	output, err := exec.Command("systemctl", args...).Output()
+	if err != nil {
+		return 0
+	}
+
+	var pid int
+	fmt.Sscanf(strings.TrimSpace(string(output)), "%d", &pid)
+	return pid
+}
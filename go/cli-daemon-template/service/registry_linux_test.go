@@ -0,0 +1,36 @@
+//go:build linux
+
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReadManagedUnit(t *testing.T) {
+	dir := t.TempDir()
+
+	managed := filepath.Join(dir, "managed.service")
+	content := managedByMarker + "\n[Unit]\nDescription=A managed service\n"
+	if err := os.WriteFile(managed, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write fixture: %v", err)
+	}
+
+	description, owned := readManagedUnit(managed)
+	if !owned {
+		t.Error("expected unit with marker comment to be reported as owned")
+	}
+	if description != "A managed service" {
+		t.Errorf("expected description %q, got %q", "A managed service", description)
+	}
+
+	foreign := filepath.Join(dir, "foreign.service")
+	if err := os.WriteFile(foreign, []byte("[Unit]\nDescription=Not ours\n"), 0644); err != nil {
+		t.Fatalf("failed to write fixture: %v", err)
+	}
+
+	if _, owned := readManagedUnit(foreign); owned {
+		t.Error("expected unit without marker comment to be reported as not owned")
+	}
+}
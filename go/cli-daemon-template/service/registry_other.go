@@ -0,0 +1,12 @@
+//go:build !linux
+
+package service
+
+import "fmt"
+
+// listManaged is only implemented for systemd today; launchd and the
+// Windows SCM don't expose an equivalent bulk "list everything" query in
+// the form this registry is built around.
+func listManaged(scope Scope) ([]ServiceInfo, error) {
+	return nil, fmt.Errorf("service registry is only supported on Linux/systemd")
+}
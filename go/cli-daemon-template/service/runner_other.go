@@ -0,0 +1,32 @@
+//go:build !windows
+
+package service
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// RunLoop drives the periodic work loop for the "run" command. On systemd,
+// launchd and OpenRC the process is just a regular process, so this is
+// plain signal handling; the Windows build dispatches through svc.Run
+// instead.
+func RunLoop(name string, interval time.Duration, tick func(), onStop func()) error {
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			tick()
+		case <-sigChan:
+			onStop()
+			return nil
+		}
+	}
+}
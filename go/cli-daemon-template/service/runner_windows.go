@@ -0,0 +1,78 @@
+//go:build windows
+
+package service
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"golang.org/x/sys/windows/svc"
+)
+
+// RunLoop drives the periodic work loop for the "run" command. When the
+// process was started by the Windows SCM it dispatches through svc.Run so
+// service control messages (stop, shutdown, interrogate) are handled
+// correctly; otherwise it falls back to the same signal-driven loop used on
+// other platforms, so the binary still works when run interactively.
+func RunLoop(name string, interval time.Duration, tick func(), onStop func()) error {
+	isWindowsService, err := svc.IsWindowsService()
+	if err != nil {
+		return err
+	}
+	if !isWindowsService {
+		return runForeground(interval, tick, onStop)
+	}
+	return svc.Run(name, &handler{interval: interval, tick: tick, onStop: onStop})
+}
+
+type handler struct {
+	interval time.Duration
+	tick     func()
+	onStop   func()
+}
+
+func (h *handler) Execute(args []string, r <-chan svc.ChangeRequest, s chan<- svc.Status) (bool, uint32) {
+	s <- svc.Status{State: svc.StartPending}
+
+	ticker := time.NewTicker(h.interval)
+	defer ticker.Stop()
+
+	s <- svc.Status{State: svc.Running, Accepts: svc.AcceptStop | svc.AcceptShutdown}
+
+	for {
+		select {
+		case <-ticker.C:
+			h.tick()
+		case c := <-r:
+			switch c.Cmd {
+			case svc.Interrogate:
+				s <- c.CurrentStatus
+			case svc.Stop, svc.Shutdown:
+				s <- svc.Status{State: svc.StopPending}
+				h.onStop()
+				s <- svc.Status{State: svc.Stopped}
+				return false, 0
+			}
+		}
+	}
+}
+
+func runForeground(interval time.Duration, tick func(), onStop func()) error {
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			tick()
+		case <-sigChan:
+			onStop()
+			return nil
+		}
+	}
+}
@@ -1,187 +1,180 @@
 package service
 
-import (
-	"fmt"
-	"os"
-	"os/exec"
-	"path/filepath"
-	"strings"
-	"text/template"
+// Scope controls whether a service is installed system-wide (the default,
+// requiring root/admin) or for the current user only.
+type Scope int
+
+const (
+	// SystemScope installs the service system-wide, e.g. via
+	// /etc/systemd/system or the Windows SCM. Requires root/admin.
+	SystemScope Scope = iota
+	// UserScope installs the service for the current user only, e.g. via
+	// `systemctl --user` or a macOS LaunchAgent. Requires no privileges.
+	// The OpenRC and Windows SCM backends have no per-user services and
+	// ignore it, installing system-wide.
+	UserScope
 )
 
-// ServiceManager handles systemd service operations
+// UnitOptions configures the generated service definition beyond the
+// defaults (root user, "always" restart, ExecStart with no extra args,
+// After=network.target). A zero-value field is left out of the generated
+// unit so the backend's own default applies.
+type UnitOptions struct {
+	User             string
+	Group            string
+	WorkingDirectory string
+	Environment      map[string]string
+	EnvironmentFile  string
+	ExecArgs         []string
+	Restart          string
+	RestartSec       int
+	After            []string
+	Requires         []string
+	WantedBy         string
+	Type             string
+	TimeoutStartSec  int
+	LimitNOFILE      int
+	// Notify sets Type=notify and has runService report readiness, status
+	// and shutdown over NOTIFY_SOCKET via NotifyReady/NotifyStatus/
+	// NotifyStopping. Takes precedence over Type when set.
+	Notify bool
+	// WatchdogSec generates WatchdogSec= on the unit and is paired with a
+	// StartWatchdog(ctx) call in runService to ping systemd before it
+	// restarts the service for being unresponsive.
+	WatchdogSec int
+}
+
+// Service is the set of operations a platform-specific service backend must
+// implement. ServiceManager delegates to whichever backend newBackend
+// selects for the current OS.
+type Service interface {
+	Start() error
+	Stop() error
+	Restart() error
+	Status() (string, error)
+	Install() error
+	Uninstall() error
+	IsInstalled() bool
+}
+
+// BinaryPathOverrider is implemented by backends that launch a binary
+// resolved from os.Executable() by default but allow pointing the generated
+// unit/script/plist at a different one instead, e.g. for staging a build
+// before installing it to its final location.
+type BinaryPathOverrider interface {
+	SetBinaryPath(path string)
+	GetBinaryPath() string
+}
+
+// FileBacked is implemented by backends whose install artifact is a single
+// file on disk, e.g. a systemd unit file, an OpenRC init script, or a
+// launchd plist.
+type FileBacked interface {
+	GetServiceFile() string
+}
+
+// ServiceManager is the platform-agnostic front door used by main.go. It
+// detects the right backend (systemd, launchd, Windows SCM, OpenRC, ...) at
+// construction time and forwards every call to it.
 type ServiceManager struct {
 	Name        string
 	Description string
-	ServiceFile string
-	BinaryPath  string
+	Scope       Scope
+	Options     UnitOptions
+
+	backend Service
 }
 
-// NewServiceManager creates a new ServiceManager instance
+// NewServiceManager creates a new system-scoped ServiceManager, selecting
+// the appropriate backend for the current platform.
 func NewServiceManager(name, description string) *ServiceManager {
-	execPath, _ := os.Executable()
+	return newServiceManager(name, description, SystemScope)
+}
+
+// NewUserServiceManager creates a ServiceManager that installs and manages
+// the service for the current user only, requiring no elevated privileges
+// (e.g. `systemctl --user` on Linux, a LaunchAgent on macOS).
+func NewUserServiceManager(name, description string) *ServiceManager {
+	return newServiceManager(name, description, UserScope)
+}
+
+func newServiceManager(name, description string, scope Scope) *ServiceManager {
 	return &ServiceManager{
 		Name:        name,
 		Description: description,
-		ServiceFile: fmt.Sprintf("/etc/systemd/system/%s.service", name),
-		BinaryPath:  execPath,
+		Scope:       scope,
+		backend:     newBackend(name, description, scope, UnitOptions{}),
 	}
 }
 
-// Start starts the service
+// WithUnitOptions sets the unit generation options and rebuilds the backend
+// so they take effect. Returns sm so it can be chained onto a constructor,
+// e.g. service.NewServiceManager(name, desc).WithUnitOptions(opts).
+func (sm *ServiceManager) WithUnitOptions(opts UnitOptions) *ServiceManager {
+	sm.Options = opts
+	sm.backend = newBackend(sm.Name, sm.Description, sm.Scope, opts)
+	return sm
+}
+
+// Start starts the service.
 func (sm *ServiceManager) Start() error {
-	return sm.systemctl("start")
+	return sm.backend.Start()
 }
 
-// Stop stops the service
+// Stop stops the service.
 func (sm *ServiceManager) Stop() error {
-	return sm.systemctl("stop")
+	return sm.backend.Stop()
 }
 
-// Restart restarts the service
+// Restart restarts the service.
 func (sm *ServiceManager) Restart() error {
-	return sm.systemctl("restart")
+	return sm.backend.Restart()
 }
 
-// Status returns the service status
+// Status returns the service status.
 func (sm *ServiceManager) Status() (string, error) {
-	cmd := exec.Command("systemctl", "is-active", sm.Name)
-	output, err := cmd.Output()
-	if err != nil {
-		// Check if service exists but is inactive
-		if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 3 {
-			return "inactive", nil
-		}
-		return "unknown", err
-	}
-	return strings.TrimSpace(string(output)), nil
+	return sm.backend.Status()
 }
 
-// Install installs the service by creating the systemd service file
+// Install installs the service using the platform's native service manager.
 func (sm *ServiceManager) Install() error {
-	// Check if running as root
-	if os.Geteuid() != 0 {
-		return fmt.Errorf("installation requires root privileges. Run with sudo")
-	}
-
-	// Create the service file
-	serviceContent := sm.generateServiceFile()
-
-	// Write the service file
-	if err := os.WriteFile(sm.ServiceFile, []byte(serviceContent), 0644); err != nil {
-		return fmt.Errorf("failed to create service file: %w", err)
-	}
-
-	// Reload systemd daemon
-	if err := sm.systemctl("daemon-reload"); err != nil {
-		return fmt.Errorf("failed to reload systemd daemon: %w", err)
-	}
-
-	// Enable the service
-	if err := sm.systemctl("enable"); err != nil {
-		return fmt.Errorf("failed to enable service: %w", err)
-	}
-
-	return nil
+	return sm.backend.Install()
 }
 
-// Uninstall removes the service
+// Uninstall removes the service.
 func (sm *ServiceManager) Uninstall() error {
-	// Check if running as root
-	if os.Geteuid() != 0 {
-		return fmt.Errorf("uninstallation requires root privileges. Run with sudo")
-	}
-
-	// Stop the service if running
-	sm.Stop()
-
-	// Disable the service
-	sm.systemctl("disable")
-
-	// Remove the service file
-	if err := os.Remove(sm.ServiceFile); err != nil && !os.IsNotExist(err) {
-		return fmt.Errorf("failed to remove service file: %w", err)
-	}
-
-	// Reload systemd daemon
-	if err := sm.systemctl("daemon-reload"); err != nil {
-		return fmt.Errorf("failed to reload systemd daemon: %w", err)
-	}
-
-	return nil
+	return sm.backend.Uninstall()
 }
 
-// systemctl executes systemctl commands
-func (sm *ServiceManager) systemctl(action string) error {
-	var cmd *exec.Cmd
-
-	switch action {
-	case "daemon-reload":
-		cmd = exec.Command("systemctl", "daemon-reload")
-	default:
-		cmd = exec.Command("systemctl", action, sm.Name)
-	}
-
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return fmt.Errorf("systemctl %s failed: %w\nOutput: %s", action, err, string(output))
-	}
-
-	return nil
-}
-
-// generateServiceFile creates the systemd service file content
-func (sm *ServiceManager) generateServiceFile() string {
-	serviceTemplate := `[Unit]
-Description={{.Description}}
-After=network.target
-StartLimitIntervalSec=0
-
-[Service]
-Type=simple
-Restart=always
-RestartSec=1
-User=root
-ExecStart={{.BinaryPath}} run
-StandardOutput=journal
-StandardError=journal
-SyslogIdentifier={{.Name}}
-
-[Install]
-WantedBy=multi-user.target
-`
-
-	tmpl, err := template.New("service").Parse(serviceTemplate)
-	if err != nil {
-		return serviceTemplate // fallback to template string
-	}
-
-	var buf strings.Builder
-	err = tmpl.Execute(&buf, sm)
-	if err != nil {
-		return serviceTemplate // fallback to template string
-	}
-
-	return buf.String()
-}
-
-// IsInstalled checks if the service is installed
+// IsInstalled reports whether the service is currently installed.
 func (sm *ServiceManager) IsInstalled() bool {
-	_, err := os.Stat(sm.ServiceFile)
-	return err == nil
+	return sm.backend.IsInstalled()
 }
 
-// GetServiceFile returns the path to the service file
-func (sm *ServiceManager) GetServiceFile() string {
-	return sm.ServiceFile
+// SetBinaryPath overrides the binary the generated unit/script/plist
+// launches, in place of the os.Executable() default. A no-op on backends
+// that don't support it.
+func (sm *ServiceManager) SetBinaryPath(path string) {
+	if o, ok := sm.backend.(BinaryPathOverrider); ok {
+		o.SetBinaryPath(path)
+	}
 }
 
-// GetBinaryPath returns the path to the binary
+// GetBinaryPath returns the binary path the backend will launch, or "" if
+// the backend doesn't support overriding it.
 func (sm *ServiceManager) GetBinaryPath() string {
-	return sm.BinaryPath
+	if o, ok := sm.backend.(BinaryPathOverrider); ok {
+		return o.GetBinaryPath()
+	}
+	return ""
 }
 
-// SetBinaryPath sets a custom binary path (useful for installation)
-func (sm *ServiceManager) SetBinaryPath(path string) {
-	sm.BinaryPath, _ = filepath.Abs(path)
+// GetServiceFile returns the path to the backend's generated unit file,
+// init script, or plist, or "" if the backend isn't file-backed (e.g. the
+// Windows SCM, which stores service config in the registry).
+func (sm *ServiceManager) GetServiceFile() string {
+	if f, ok := sm.backend.(FileBacked); ok {
+		return f.GetServiceFile()
+	}
+	return ""
 }
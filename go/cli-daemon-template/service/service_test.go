@@ -1,10 +1,6 @@
 package service
 
-import (
-	"os"
-	"path/filepath"
-	"testing"
-)
+import "testing"
 
 func TestNewServiceManager(t *testing.T) {
 	name := "test-service"
@@ -20,126 +16,65 @@ func TestNewServiceManager(t *testing.T) {
 		t.Errorf("Expected description %s, got %s", description, sm.Description)
 	}
 
-	expectedServiceFile := "/etc/systemd/system/test-service.service"
-	if sm.ServiceFile != expectedServiceFile {
-		t.Errorf("Expected service file %s, got %s", expectedServiceFile, sm.ServiceFile)
-	}
-
-	if sm.BinaryPath == "" {
-		t.Error("Expected binary path to be set")
+	if sm.backend == nil {
+		t.Error("Expected a platform backend to be selected")
 	}
 }
 
-func TestGenerateServiceFile(t *testing.T) {
+func TestServiceManagerDelegatesToBackend(t *testing.T) {
 	sm := NewServiceManager("test-service", "Test Description")
-	sm.BinaryPath = "/usr/local/bin/test-service"
-
-	content := sm.generateServiceFile()
-
-	// Check that the content contains expected sections
-	expectedSections := []string{
-		"[Unit]",
-		"[Service]",
-		"[Install]",
-		"Description=Test Description",
-		"ExecStart=/usr/local/bin/test-service run",
-		"WantedBy=multi-user.target",
-	}
 
-	for _, section := range expectedSections {
-		if !containsString(content, section) {
-			t.Errorf("Service file content missing expected section: %s", section)
-		}
+	if _, ok := sm.backend.(Service); !ok {
+		t.Error("Expected backend to implement the Service interface")
 	}
-}
-
-func TestSetBinaryPath(t *testing.T) {
-	sm := NewServiceManager("test-service", "Test Description")
 
-	testPath := "/custom/path/to/binary"
-	sm.SetBinaryPath(testPath)
+	// IsInstalled should never panic even when nothing is installed.
+	_ = sm.IsInstalled()
 
-	absPath, _ := filepath.Abs(testPath)
-	if sm.BinaryPath != absPath {
-		t.Errorf("Expected binary path %s, got %s", absPath, sm.BinaryPath)
+	// Calls are forwarded to the backend and return its results.
+	stubbed := &ServiceManager{Name: "test-service", backend: stubBackend{}}
+	if status, err := stubbed.Status(); err != nil || status != "unknown" {
+		t.Errorf("Expected Status to return the backend's %q, got %q (err: %v)", "unknown", status, err)
 	}
 }
 
-func TestIsInstalled(t *testing.T) {
-	sm := NewServiceManager("test-service", "Test Description")
-
-	// For a non-existent service file, should return false
-	if sm.IsInstalled() {
-		t.Error("Expected service to not be installed")
-	}
+// fileBackedStub implements Service, BinaryPathOverrider and FileBacked,
+// standing in for a backend like systemd/OpenRC/launchd that generates a
+// file pointing at an overridable binary.
+type fileBackedStub struct {
+	stubBackend
+	binaryPath  string
+	serviceFile string
+}
 
-	// Create a temporary service file to test positive case
-	tempDir := t.TempDir()
-	tempServiceFile := filepath.Join(tempDir, "test-service.service")
+func (f *fileBackedStub) SetBinaryPath(path string) { f.binaryPath = path }
+func (f *fileBackedStub) GetBinaryPath() string     { return f.binaryPath }
+func (f *fileBackedStub) GetServiceFile() string    { return f.serviceFile }
 
-	// Create the file
-	file, err := os.Create(tempServiceFile)
-	if err != nil {
-		t.Fatalf("Failed to create temp service file: %v", err)
-	}
-	file.Close()
+func TestServiceManagerBinaryPathOverride(t *testing.T) {
+	sm := &ServiceManager{Name: "test-service", backend: &fileBackedStub{serviceFile: "/etc/init.d/test-service"}}
 
-	// Update the service file path to point to our temp file
-	sm.ServiceFile = tempServiceFile
+	sm.SetBinaryPath("/custom/path/to/binary")
 
-	// Now it should be installed
-	if !sm.IsInstalled() {
-		t.Error("Expected service to be installed")
+	if got := sm.GetBinaryPath(); got != "/custom/path/to/binary" {
+		t.Errorf("Expected binary path /custom/path/to/binary, got %s", got)
 	}
-}
-
-func TestGetServiceFile(t *testing.T) {
-	sm := NewServiceManager("test-service", "Test Description")
-
-	expected := "/etc/systemd/system/test-service.service"
-	if sm.GetServiceFile() != expected {
-		t.Errorf("Expected service file %s, got %s", expected, sm.GetServiceFile())
+	if got := sm.GetServiceFile(); got != "/etc/init.d/test-service" {
+		t.Errorf("Expected service file /etc/init.d/test-service, got %s", got)
 	}
 }
 
-func TestGetBinaryPath(t *testing.T) {
-	sm := NewServiceManager("test-service", "Test Description")
+func TestServiceManagerBinaryPathOverrideUnsupportedByBackend(t *testing.T) {
+	sm := &ServiceManager{Name: "test-service", backend: stubBackend{}}
 
-	if sm.GetBinaryPath() == "" {
-		t.Error("Expected binary path to be set")
-	}
-}
+	// Should not panic even though stubBackend doesn't implement
+	// BinaryPathOverrider or FileBacked.
+	sm.SetBinaryPath("/custom/path/to/binary")
 
-// Helper function to check if a string contains a substring
-func containsString(s, substr string) bool {
-	return len(s) >= len(substr) && s[:len(substr)] == substr ||
-		len(s) > len(substr) && containsString(s[1:], substr)
-}
-
-// Alternative implementation using a simple search
-func containsString2(s, substr string) bool {
-	for i := 0; i <= len(s)-len(substr); i++ {
-		if s[i:i+len(substr)] == substr {
-			return true
-		}
+	if got := sm.GetBinaryPath(); got != "" {
+		t.Errorf("Expected empty binary path for an unsupported backend, got %s", got)
+	}
+	if got := sm.GetServiceFile(); got != "" {
+		t.Errorf("Expected empty service file for an unsupported backend, got %s", got)
 	}
-	return false
-}
-
-// Benchmark test to compare containsString implementations
-func BenchmarkContainsString(b *testing.B) {
-	text := "This is a test string with some content to search through"
-	search := "test"
-
-	b.Run("Implementation1", func(b *testing.B) {
-		for i := 0; i < b.N; i++ {
-			containsString(text, search)
-		}
-	})
-
-	b.Run("Implementation2", func(b *testing.B) {
-		for i := 0; i < b.N; i++ {
-			containsString2(text, search)
-		}
-	})
 }
@@ -0,0 +1,378 @@
+//go:build linux
+
+package service
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+// systemdService manages a service via systemd unit files and systemctl.
+// This is the original, and still default, Linux backend.
+type systemdService struct {
+	Name        string
+	Description string
+	ServiceFile string
+	BinaryPath  string
+	Scope       Scope
+	Options     UnitOptions
+}
+
+func newSystemdService(name, description string, scope Scope, opts UnitOptions) *systemdService {
+	execPath, _ := os.Executable()
+	return &systemdService{
+		Name:        name,
+		Description: description,
+		ServiceFile: serviceFilePath(name, scope),
+		BinaryPath:  execPath,
+		Scope:       scope,
+		Options:     opts,
+	}
+}
+
+// unitDir returns the directory systemd unit files live in for the given
+// scope: /etc/systemd/system for the system instance, ~/.config/systemd/user
+// for the per-user instance.
+func unitDir(scope Scope) string {
+	if scope == UserScope {
+		home, _ := os.UserHomeDir()
+		return filepath.Join(home, ".config", "systemd", "user")
+	}
+	return "/etc/systemd/system"
+}
+
+// serviceFilePath returns the unit file location for the given scope.
+func serviceFilePath(name string, scope Scope) string {
+	return filepath.Join(unitDir(scope), fmt.Sprintf("%s.service", name))
+}
+
+// Start starts the service.
+func (sm *systemdService) Start() error {
+	return sm.systemctl("start")
+}
+
+// Stop stops the service.
+func (sm *systemdService) Stop() error {
+	return sm.systemctl("stop")
+}
+
+// Restart restarts the service.
+func (sm *systemdService) Restart() error {
+	return sm.systemctl("restart")
+}
+
+// Status returns the service status.
+func (sm *systemdService) Status() (string, error) {
+	args := []string{}
+	if sm.Scope == UserScope {
+		args = append(args, "--user")
+	}
+	args = append(args, "is-active", sm.Name)
+
+	cmd := exec.Command("systemctl", args...)
+	output, err := cmd.Output()
+	if err != nil {
+		// Check if service exists but is inactive
+		if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 3 {
+			return "inactive", nil
+		}
+		return "unknown", err
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// Install installs the service by creating the systemd service file.
+func (sm *systemdService) Install() error {
+	// User-scope units live under the caller's home directory and don't
+	// need root; system-scope units do.
+	if sm.Scope == SystemScope && os.Geteuid() != 0 {
+		return fmt.Errorf("installation requires root privileges. Run with sudo")
+	}
+
+	if sm.Scope == UserScope {
+		if err := os.MkdirAll(filepath.Dir(sm.ServiceFile), 0755); err != nil {
+			return fmt.Errorf("failed to create unit directory: %w", err)
+		}
+	}
+
+	// Create the service file
+	serviceContent := sm.generateServiceFile()
+
+	// Write the service file
+	if err := os.WriteFile(sm.ServiceFile, []byte(serviceContent), 0644); err != nil {
+		return fmt.Errorf("failed to create service file: %w", err)
+	}
+
+	// Reload systemd daemon
+	if err := sm.systemctl("daemon-reload"); err != nil {
+		return fmt.Errorf("failed to reload systemd daemon: %w", err)
+	}
+
+	// Enable the service
+	if err := sm.systemctl("enable"); err != nil {
+		return fmt.Errorf("failed to enable service: %w", err)
+	}
+
+	return nil
+}
+
+// Uninstall removes the service.
+func (sm *systemdService) Uninstall() error {
+	if sm.Scope == SystemScope && os.Geteuid() != 0 {
+		return fmt.Errorf("uninstallation requires root privileges. Run with sudo")
+	}
+
+	// Stop the service if running
+	sm.Stop()
+
+	// Disable the service
+	sm.systemctl("disable")
+
+	// Remove the service file
+	if err := os.Remove(sm.ServiceFile); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove service file: %w", err)
+	}
+
+	// Reload systemd daemon
+	if err := sm.systemctl("daemon-reload"); err != nil {
+		return fmt.Errorf("failed to reload systemd daemon: %w", err)
+	}
+
+	return nil
+}
+
+// systemctl executes systemctl commands, adding --user when the service is
+// scoped to the current user.
+func (sm *systemdService) systemctl(action string) error {
+	args := []string{}
+	if sm.Scope == UserScope {
+		args = append(args, "--user")
+	}
+
+	switch action {
+	case "daemon-reload":
+		args = append(args, "daemon-reload")
+	default:
+		args = append(args, action, sm.Name)
+	}
+
+	cmd := exec.Command("systemctl", args...)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("systemctl %s failed: %w\nOutput: %s", action, err, string(output))
+	}
+
+	return nil
+}
+
+// unitView is the data handed to the unit file template. It applies
+// UnitOptions on top of this backend's defaults, so the template itself
+// only has to deal with "is this set or not", not scope/default logic.
+type unitView struct {
+	Description string
+	BinaryPath  string
+	Name        string
+
+	Type             string
+	Restart          string
+	RestartSec       int
+	User             string
+	Group            string
+	WorkingDirectory string
+	Environment      map[string]string
+	EnvironmentFile  string
+	ExecArgs         []string
+	After            []string
+	Requires         []string
+	WantedBy         string
+	TimeoutStartSec  int
+	LimitNOFILE      int
+	WatchdogSec      int
+}
+
+// view builds the unitView for this service, applying Options over the
+// scope-appropriate defaults (root user + multi-user.target for system
+// scope, no User= line + default.target for user scope).
+func (sm *systemdService) view() unitView {
+	v := unitView{
+		Description: sm.Description,
+		BinaryPath:  sm.BinaryPath,
+		Name:        sm.Name,
+		Type:        "simple",
+		Restart:     "always",
+		RestartSec:  1,
+		After:       []string{"network.target"},
+		WantedBy:    "multi-user.target",
+	}
+	if sm.Scope == SystemScope {
+		v.User = "root"
+	} else {
+		v.WantedBy = "default.target"
+	}
+
+	o := sm.Options
+	if o.Type != "" {
+		v.Type = o.Type
+	}
+	if o.Restart != "" {
+		v.Restart = o.Restart
+	}
+	if o.RestartSec != 0 {
+		v.RestartSec = o.RestartSec
+	}
+	if o.User != "" {
+		v.User = o.User
+	}
+	if o.Group != "" {
+		v.Group = o.Group
+	}
+	if o.WorkingDirectory != "" {
+		v.WorkingDirectory = o.WorkingDirectory
+	}
+	if len(o.Environment) > 0 {
+		v.Environment = o.Environment
+	}
+	if o.EnvironmentFile != "" {
+		v.EnvironmentFile = o.EnvironmentFile
+	}
+	if len(o.ExecArgs) > 0 {
+		v.ExecArgs = o.ExecArgs
+	}
+	if len(o.After) > 0 {
+		v.After = o.After
+	}
+	if len(o.Requires) > 0 {
+		v.Requires = o.Requires
+	}
+	if o.WantedBy != "" {
+		v.WantedBy = o.WantedBy
+	}
+	if o.TimeoutStartSec != 0 {
+		v.TimeoutStartSec = o.TimeoutStartSec
+	}
+	if o.LimitNOFILE != 0 {
+		v.LimitNOFILE = o.LimitNOFILE
+	}
+	if o.Notify {
+		v.Type = "notify"
+	}
+	if o.WatchdogSec != 0 {
+		v.WatchdogSec = o.WatchdogSec
+	}
+	return v
+}
+
+// managedByMarker is written into every generated unit file so Registry can
+// tell our units apart from the rest of what's installed on the box.
+const managedByMarker = "# managed-by: cli-daemon-template"
+
+const serviceFileTemplate = managedByMarker + `
+[Unit]
+Description={{.Description}}
+{{range .After}}After={{.}}
+{{end}}{{range .Requires}}Requires={{.}}
+{{end}}StartLimitIntervalSec=0
+
+[Service]
+Type={{.Type}}
+Restart={{.Restart}}
+RestartSec={{.RestartSec}}
+{{if .User}}User={{.User}}
+{{end}}{{if .Group}}Group={{.Group}}
+{{end}}{{if .WorkingDirectory}}WorkingDirectory={{.WorkingDirectory}}
+{{end}}{{range $key, $value := .Environment}}Environment={{envAssignment $key $value}}
+{{end}}{{if .EnvironmentFile}}EnvironmentFile={{.EnvironmentFile}}
+{{end}}ExecStart={{quote .BinaryPath}} run {{quote .Name}}{{range .ExecArgs}} {{quote .}}{{end}}
+{{if .TimeoutStartSec}}TimeoutStartSec={{.TimeoutStartSec}}
+{{end}}{{if .LimitNOFILE}}LimitNOFILE={{.LimitNOFILE}}
+{{end}}{{if .WatchdogSec}}WatchdogSec={{.WatchdogSec}}
+{{end}}StandardOutput=journal
+StandardError=journal
+SyslogIdentifier={{.Name}}
+
+[Install]
+WantedBy={{.WantedBy}}
+`
+
+// unitTemplateFuncs are the helpers serviceFileTemplate uses to quote values
+// that might contain whitespace, so Environment=/ExecStart= lines stay one
+// systemd unit-file token (or one argv entry) per value instead of being
+// silently split or truncated. See systemd.syntax(5) on C-style quoting.
+var unitTemplateFuncs = template.FuncMap{
+	"quote": quoteUnitValue,
+	"envAssignment": func(key, value string) string {
+		return quoteUnitValue(key + "=" + value)
+	},
+}
+
+// quoteUnitValue wraps s in double quotes, escaping backslashes, double
+// quotes and newlines, if it contains whitespace or a character that would
+// otherwise let systemd split it into more than one token or one more
+// physical line. Values with nothing to escape are returned unchanged, so
+// the common case stays readable. A raw newline must be escaped rather than
+// just quoted: systemd splits unit files into physical lines before it ever
+// looks at quoting, so an unescaped "\n" would terminate the directive
+// early regardless of surrounding quotes.
+func quoteUnitValue(s string) string {
+	if !strings.ContainsAny(s, " \t\"'\\\n\r") {
+		return s
+	}
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"', '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// generateServiceFile creates the systemd service file content, applying
+// any UnitOptions on top of the scope's defaults.
+func (sm *systemdService) generateServiceFile() string {
+	tmpl, err := template.New("service").Funcs(unitTemplateFuncs).Parse(serviceFileTemplate)
+	if err != nil {
+		return serviceFileTemplate // fallback to template string
+	}
+
+	var buf strings.Builder
+	if err := tmpl.Execute(&buf, sm.view()); err != nil {
+		return serviceFileTemplate // fallback to template string
+	}
+
+	return buf.String()
+}
+
+// IsInstalled checks if the service is installed
+func (sm *systemdService) IsInstalled() bool {
+	_, err := os.Stat(sm.ServiceFile)
+	return err == nil
+}
+
+// SetBinaryPath overrides the binary ExecStart launches.
+func (sm *systemdService) SetBinaryPath(path string) {
+	sm.BinaryPath, _ = filepath.Abs(path)
+}
+
+// GetBinaryPath returns the binary ExecStart launches.
+func (sm *systemdService) GetBinaryPath() string {
+	return sm.BinaryPath
+}
+
+// GetServiceFile returns the path to the unit file.
+func (sm *systemdService) GetServiceFile() string {
+	return sm.ServiceFile
+}
@@ -0,0 +1,184 @@
+//go:build linux
+
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNewSystemdService(t *testing.T) {
+	name := "test-service"
+	description := "Test service description"
+
+	sm := newSystemdService(name, description, SystemScope, UnitOptions{})
+
+	if sm.Name != name {
+		t.Errorf("Expected name %s, got %s", name, sm.Name)
+	}
+
+	if sm.Description != description {
+		t.Errorf("Expected description %s, got %s", description, sm.Description)
+	}
+
+	expectedServiceFile := "/etc/systemd/system/test-service.service"
+	if sm.ServiceFile != expectedServiceFile {
+		t.Errorf("Expected service file %s, got %s", expectedServiceFile, sm.ServiceFile)
+	}
+
+	if sm.BinaryPath == "" {
+		t.Error("Expected binary path to be set")
+	}
+}
+
+func TestSystemdGenerateServiceFile(t *testing.T) {
+	sm := newSystemdService("test-service", "Test Description", SystemScope, UnitOptions{})
+	sm.BinaryPath = "/usr/local/bin/test-service"
+
+	content := sm.generateServiceFile()
+
+	expectedSections := []string{
+		"[Unit]",
+		"[Service]",
+		"[Install]",
+		"Description=Test Description",
+		"ExecStart=/usr/local/bin/test-service run test-service",
+		"WantedBy=multi-user.target",
+	}
+
+	for _, section := range expectedSections {
+		if !strings.Contains(content, section) {
+			t.Errorf("Service file content missing expected section: %s", section)
+		}
+	}
+}
+
+func TestNewSystemdServiceUserScope(t *testing.T) {
+	sm := newSystemdService("test-service", "Test Description", UserScope, UnitOptions{})
+
+	home, _ := os.UserHomeDir()
+	expected := filepath.Join(home, ".config", "systemd", "user", "test-service.service")
+	if sm.ServiceFile != expected {
+		t.Errorf("Expected user-scope service file %s, got %s", expected, sm.ServiceFile)
+	}
+
+	content := sm.generateServiceFile()
+	if strings.Contains(content, "User=root") {
+		t.Error("Expected user-scope unit to omit User=root")
+	}
+	if !strings.Contains(content, "WantedBy=default.target") {
+		t.Error("Expected user-scope unit to target default.target")
+	}
+}
+
+func TestSystemdGenerateServiceFileWithOptions(t *testing.T) {
+	sm := newSystemdService("test-service", "Test Description", SystemScope, UnitOptions{
+		User:             "svcuser",
+		WorkingDirectory: "/srv/test-service",
+		ExecArgs:         []string{"--config", "/etc/test-service.yaml"},
+		Restart:          "on-failure",
+		After:            []string{"network-online.target"},
+		TimeoutStartSec:  30,
+	})
+
+	content := sm.generateServiceFile()
+
+	expected := []string{
+		"User=svcuser",
+		"WorkingDirectory=/srv/test-service",
+		"ExecStart=" + sm.BinaryPath + " run test-service --config /etc/test-service.yaml",
+		"Restart=on-failure",
+		"After=network-online.target",
+		"TimeoutStartSec=30",
+	}
+	for _, want := range expected {
+		if !strings.Contains(content, want) {
+			t.Errorf("Service file missing expected line %q, got:\n%s", want, content)
+		}
+	}
+	if strings.Contains(content, "After=network.target") {
+		t.Error("Expected custom After= to replace the default, not augment it")
+	}
+}
+
+func TestSystemdGenerateServiceFileQuotesWhitespace(t *testing.T) {
+	sm := newSystemdService("test-service", "Test Description", SystemScope, UnitOptions{
+		Environment: map[string]string{"GREETING": "hello world"},
+		ExecArgs:    []string{"--message", "hello there"},
+	})
+
+	content := sm.generateServiceFile()
+
+	expected := []string{
+		`Environment="GREETING=hello world"`,
+		`ExecStart=` + sm.BinaryPath + ` run test-service --message "hello there"`,
+	}
+	for _, want := range expected {
+		if !strings.Contains(content, want) {
+			t.Errorf("Service file missing expected line %q, got:\n%s", want, content)
+		}
+	}
+}
+
+func TestSystemdGenerateServiceFileEscapesNewlines(t *testing.T) {
+	sm := newSystemdService("test-service", "Test Description", SystemScope, UnitOptions{
+		Environment: map[string]string{"PAYLOAD": "a\n[Service]\nExecStart=/bin/evil"},
+	})
+
+	content := sm.generateServiceFile()
+
+	if !strings.Contains(content, `Environment="PAYLOAD=a\n[Service]\nExecStart=/bin/evil"`) {
+		t.Errorf("expected embedded newline to be escaped as \\n within the quoted value, got:\n%s", content)
+	}
+	for _, line := range strings.Split(content, "\n") {
+		if line == "ExecStart=/bin/evil" {
+			t.Error("expected the embedded newline not to start a new physical directive line")
+		}
+	}
+}
+
+func TestSystemdSetBinaryPath(t *testing.T) {
+	sm := newSystemdService("test-service", "Test Description", SystemScope, UnitOptions{})
+
+	testPath := "/custom/path/to/binary"
+	sm.SetBinaryPath(testPath)
+
+	absPath, _ := filepath.Abs(testPath)
+	if sm.GetBinaryPath() != absPath {
+		t.Errorf("Expected binary path %s, got %s", absPath, sm.GetBinaryPath())
+	}
+}
+
+func TestSystemdGetServiceFile(t *testing.T) {
+	sm := newSystemdService("test-service", "Test Description", SystemScope, UnitOptions{})
+
+	expected := "/etc/systemd/system/test-service.service"
+	if sm.GetServiceFile() != expected {
+		t.Errorf("Expected service file %s, got %s", expected, sm.GetServiceFile())
+	}
+}
+
+func TestSystemdIsInstalled(t *testing.T) {
+	sm := newSystemdService("test-service", "Test Description", SystemScope, UnitOptions{})
+
+	if sm.IsInstalled() {
+		t.Error("Expected service to not be installed")
+	}
+
+	tempDir := t.TempDir()
+	tempServiceFile := filepath.Join(tempDir, "test-service.service")
+
+	file, err := os.Create(tempServiceFile)
+	if err != nil {
+		t.Fatalf("Failed to create temp service file: %v", err)
+	}
+	file.Close()
+
+	sm.ServiceFile = tempServiceFile
+
+	if !sm.IsInstalled() {
+		t.Error("Expected service to be installed")
+	}
+}
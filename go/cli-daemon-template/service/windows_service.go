@@ -0,0 +1,170 @@
+//go:build windows
+
+package service
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/sys/windows/svc"
+	"golang.org/x/sys/windows/svc/mgr"
+)
+
+// windowsService manages a service via the Windows Service Control Manager.
+type windowsService struct {
+	Name        string
+	Description string
+	BinaryPath  string
+	Options     UnitOptions
+}
+
+func newWindowsService(name, description string, opts UnitOptions) *windowsService {
+	execPath, _ := os.Executable()
+	return &windowsService{
+		Name:        name,
+		Description: description,
+		BinaryPath:  execPath,
+		Options:     opts,
+	}
+}
+
+// Start starts the service.
+func (sm *windowsService) Start() error {
+	m, err := mgr.Connect()
+	if err != nil {
+		return fmt.Errorf("failed to connect to service manager: %w", err)
+	}
+	defer m.Disconnect()
+
+	s, err := m.OpenService(sm.Name)
+	if err != nil {
+		return fmt.Errorf("failed to open service: %w", err)
+	}
+	defer s.Close()
+
+	if err := s.Start("run", sm.Name); err != nil {
+		return fmt.Errorf("failed to start service: %w", err)
+	}
+	return nil
+}
+
+// Stop stops the service.
+func (sm *windowsService) Stop() error {
+	m, err := mgr.Connect()
+	if err != nil {
+		return fmt.Errorf("failed to connect to service manager: %w", err)
+	}
+	defer m.Disconnect()
+
+	s, err := m.OpenService(sm.Name)
+	if err != nil {
+		return fmt.Errorf("failed to open service: %w", err)
+	}
+	defer s.Close()
+
+	if _, err := s.Control(svc.Stop); err != nil {
+		return fmt.Errorf("failed to stop service: %w", err)
+	}
+	return nil
+}
+
+// Restart restarts the service.
+func (sm *windowsService) Restart() error {
+	if err := sm.Stop(); err != nil {
+		return err
+	}
+	return sm.Start()
+}
+
+// Status returns the service status.
+func (sm *windowsService) Status() (string, error) {
+	m, err := mgr.Connect()
+	if err != nil {
+		return "unknown", fmt.Errorf("failed to connect to service manager: %w", err)
+	}
+	defer m.Disconnect()
+
+	s, err := m.OpenService(sm.Name)
+	if err != nil {
+		return "inactive", nil
+	}
+	defer s.Close()
+
+	status, err := s.Query()
+	if err != nil {
+		return "unknown", err
+	}
+
+	if status.State == svc.Running {
+		return "active", nil
+	}
+	return "inactive", nil
+}
+
+// Install registers the service with the Windows SCM.
+func (sm *windowsService) Install() error {
+	m, err := mgr.Connect()
+	if err != nil {
+		return fmt.Errorf("failed to connect to service manager: %w", err)
+	}
+	defer m.Disconnect()
+
+	s, err := m.CreateService(sm.Name, sm.BinaryPath, mgr.Config{
+		DisplayName: sm.Name,
+		Description: sm.Description,
+		StartType:   mgr.StartAutomatic,
+	}, append([]string{"run", sm.Name}, sm.Options.ExecArgs...)...)
+	if err != nil {
+		return fmt.Errorf("failed to create service: %w", err)
+	}
+	defer s.Close()
+
+	return nil
+}
+
+// Uninstall removes the service from the Windows SCM.
+func (sm *windowsService) Uninstall() error {
+	m, err := mgr.Connect()
+	if err != nil {
+		return fmt.Errorf("failed to connect to service manager: %w", err)
+	}
+	defer m.Disconnect()
+
+	s, err := m.OpenService(sm.Name)
+	if err != nil {
+		return fmt.Errorf("failed to open service: %w", err)
+	}
+	defer s.Close()
+
+	if err := s.Delete(); err != nil {
+		return fmt.Errorf("failed to delete service: %w", err)
+	}
+	return nil
+}
+
+// IsInstalled checks if the service is registered with the SCM.
+func (sm *windowsService) IsInstalled() bool {
+	m, err := mgr.Connect()
+	if err != nil {
+		return false
+	}
+	defer m.Disconnect()
+
+	s, err := m.OpenService(sm.Name)
+	if err != nil {
+		return false
+	}
+	s.Close()
+	return true
+}
+
+// SetBinaryPath overrides the binary the SCM registers the service against.
+func (sm *windowsService) SetBinaryPath(path string) {
+	sm.BinaryPath, _ = filepath.Abs(path)
+}
+
+// GetBinaryPath returns the binary the SCM registers the service against.
+func (sm *windowsService) GetBinaryPath() string {
+	return sm.BinaryPath
+}